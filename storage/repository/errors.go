@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"fyne.io/fyne"
+)
+
+var (
+	// ErrSchemeNotRegistered is returned when no repository is registered
+	// to handle the scheme of the URI being looked up.
+	//
+	// Since 2.1
+	ErrSchemeNotRegistered = errors.New("no repository is registered for the given scheme")
+
+	// ErrInvalidURI is returned when a URI fails structural validation,
+	// for example because it has no scheme, or its scheme contains
+	// characters not permitted by RFC3986.
+	//
+	// Since 2.1
+	ErrInvalidURI = errors.New("URI is not structurally valid")
+
+	// ErrRepositoryUnavailable is returned when the repository registered
+	// for a URI's scheme implements Validator and rejects the URI.
+	//
+	// Since 2.1
+	ErrRepositoryUnavailable = errors.New("repository is not available for the given URI")
+)
+
+// Validator is an optional interface which a Repository may implement in
+// order to reject URIs which are structurally valid but not acceptable to
+// this particular repository - for example, a repository backed by a fixed
+// set of named resources may want to reject a URI referring to a name it
+// does not recognize before any read or write method is called against it.
+//
+// Since 2.1
+type Validator interface {
+	// ValidateURI returns an error if u is not an acceptable URI for this
+	// repository to operate on, or nil if it is acceptable.
+	ValidateURI(u fyne.URI) error
+}
+
+// schemePattern matches the RFC3986 definition of a URI scheme:
+//
+//	scheme = ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
+
+// validateURIStructure performs structural validation of u, independent of
+// any particular repository, returning an error wrapping ErrInvalidURI if
+// it fails.
+func validateURIStructure(u fyne.URI) error {
+	if u == nil {
+		return fmt.Errorf("%w: URI is nil", ErrInvalidURI)
+	}
+
+	scheme := u.Scheme()
+	if scheme == "" {
+		return fmt.Errorf("%w: URI has no scheme", ErrInvalidURI)
+	}
+
+	if !schemePattern.MatchString(scheme) {
+		return fmt.Errorf("%w: scheme %q contains characters not permitted by RFC3986", ErrInvalidURI, scheme)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne"
+)
+
+func TestValidateURIStructure(t *testing.T) {
+	cases := []struct {
+		name    string
+		uri     *fakeURI
+		wantErr bool
+	}{
+		{name: "valid scheme", uri: &fakeURI{scheme: "file"}, wantErr: false},
+		{name: "valid scheme with digits and punctuation", uri: &fakeURI{scheme: "x-scheme+1.0"}, wantErr: false},
+		{name: "empty scheme", uri: &fakeURI{scheme: ""}, wantErr: true},
+		{name: "scheme with space", uri: &fakeURI{scheme: "not valid"}, wantErr: true},
+		{name: "scheme starting with a digit", uri: &fakeURI{scheme: "1file"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateURIStructure(c.uri)
+			if c.wantErr && !errors.Is(err, ErrInvalidURI) {
+				t.Fatalf("expected ErrInvalidURI, got %v", err)
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNamespace_LookupInvalidURI(t *testing.T) {
+	ns := NewNamespace()
+
+	_, err := ns.Lookup(&fakeURI{scheme: "not valid"})
+	if !errors.Is(err, ErrInvalidURI) {
+		t.Fatalf("expected ErrInvalidURI, got %v", err)
+	}
+}
+
+func TestNamespace_LookupSchemeNotRegistered(t *testing.T) {
+	ns := NewNamespace()
+
+	_, err := ns.Lookup(&fakeURI{scheme: "unregistered"})
+	if !errors.Is(err, ErrSchemeNotRegistered) {
+		t.Fatalf("expected ErrSchemeNotRegistered, got %v", err)
+	}
+}
+
+// rejectingRepository implements Validator and rejects every URI.
+type rejectingRepository struct {
+	fakeRepository
+}
+
+func (rejectingRepository) ValidateURI(u fyne.URI) error {
+	return errors.New("no thanks")
+}
+
+func TestNamespace_LookupRepositoryUnavailable(t *testing.T) {
+	ns := NewNamespace()
+	ns.Register("test", rejectingRepository{})
+
+	_, err := ns.Lookup(&fakeURI{scheme: "test"})
+	if !errors.Is(err, ErrRepositoryUnavailable) {
+		t.Fatalf("expected ErrRepositoryUnavailable, got %v", err)
+	}
+}
+
+func TestNamespace_LookupSuccess(t *testing.T) {
+	ns := NewNamespace()
+	ns.Register("test", fakeRepository{})
+
+	repo, err := ns.Lookup(&fakeURI{scheme: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo == nil {
+		t.Fatal("expected a non-nil repository")
+	}
+}
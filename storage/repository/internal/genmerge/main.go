@@ -0,0 +1,111 @@
+// Command genmerge generates ../../merge_generated.go, which implements
+// composeCapabilities: a function that, given the *Only value for each
+// extension interface a wrapped Repository is entitled to (or nil, if
+// neither the wrapper nor the repository it wraps implements it), returns a
+// Repository whose concrete type implements exactly that set of extension
+// interfaces in addition to the base Repository interface.
+//
+// A hand-written switch over every combination of N extension interfaces
+// requires 2^N cases, each a distinct anonymous struct literal, because Go
+// has no way to compose an interface's method set at runtime. Rather than
+// hand-maintain that combinatorial table, it is generated from the small
+// capabilities table below - adding a new extension interface to the
+// repository package only means appending one entry here and re-running
+// `go generate`.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+)
+
+// capability describes one optional extension interface that
+// composeCapabilities knows how to preserve. field is the name of the
+// corresponding "Only" interface declared in repository.go (the subset of
+// the extension interface's methods that does not re-declare Repository's
+// methods), and param is the composeCapabilities parameter name that
+// carries a value of it.
+type capability struct {
+	field string
+	param string
+}
+
+var capabilities = []capability{
+	{field: "writeableOnly", param: "w"},
+	{field: "listableOnly", param: "l"},
+	{field: "hierarchicalOnly", param: "h"},
+	{field: "copyableOnly", param: "c"},
+	{field: "movableOnly", param: "m"},
+}
+
+const outFile = "merge_generated.go"
+
+func main() {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by storage/repository/internal/genmerge; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package repository")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// composeCapabilities returns a Repository which uses wrapped to satisfy")
+	fmt.Fprintln(&buf, "// the base Repository methods, and which additionally implements exactly")
+	fmt.Fprintln(&buf, "// the extension interfaces whose corresponding argument is non-nil. It is")
+	fmt.Fprintln(&buf, "// called by mergeCapabilities once it has determined, for each extension")
+	fmt.Fprintln(&buf, "// interface, whether wrapped, the repository it wraps, or neither")
+	fmt.Fprintln(&buf, "// implements it.")
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintln(&buf, "// This function is generated - see internal/genmerge.")
+
+	fmt.Fprint(&buf, "func composeCapabilities(wrapped Repository")
+	for _, c := range capabilities {
+		fmt.Fprintf(&buf, ", %s %s", c.param, c.field)
+	}
+	fmt.Fprintln(&buf, ") Repository {")
+
+	fmt.Fprintln(&buf, "\tkey := 0")
+	for i, c := range capabilities {
+		fmt.Fprintf(&buf, "\tif %s != nil {\n\t\tkey |= %d\n\t}\n", c.param, 1<<i)
+	}
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "\tswitch key {")
+
+	for mask := 0; mask < (1 << len(capabilities)); mask++ {
+		fmt.Fprintf(&buf, "\tcase %d:\n", mask)
+		if mask == 0 {
+			fmt.Fprintln(&buf, "\t\treturn wrapped")
+			continue
+		}
+
+		fmt.Fprintln(&buf, "\t\treturn struct {")
+		fmt.Fprintln(&buf, "\t\t\tRepository")
+		for i, c := range capabilities {
+			if mask&(1<<i) != 0 {
+				fmt.Fprintf(&buf, "\t\t\t%s\n", c.field)
+			}
+		}
+		fmt.Fprintln(&buf, "\t\t}{")
+		fmt.Fprintln(&buf, "\t\t\twrapped,")
+		for i, c := range capabilities {
+			if mask&(1<<i) != 0 {
+				fmt.Fprintf(&buf, "\t\t\t%s,\n", c.param)
+			}
+		}
+		fmt.Fprintln(&buf, "\t\t}")
+	}
+	fmt.Fprintln(&buf, "\t}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "\treturn wrapped")
+	fmt.Fprintln(&buf, "}")
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(outFile, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne"
+)
+
+// Namespace is a collection of repositories retrievable by URI scheme. It
+// owns the scheme->Repository mapping that the package-level Register(),
+// RegisterMiddleware() and RegisteredRepository() functions operate on via
+// defaultNamespace.
+//
+// Most applications will never need to create a Namespace directly - the
+// default namespace, manipulated through the package-level functions, is
+// sufficient. A Namespace is useful when an application wants an isolated
+// set of repositories, for example to mock an entire storage stack in a
+// test, or to sandbox the repositories visible to part of an application.
+//
+// Since 2.1
+type Namespace struct {
+	lock            sync.RWMutex
+	repositories    map[string]Repository
+	middlewareChain map[string][]RepositoryMiddleware
+}
+
+// NewNamespace returns a Namespace with no repositories registered.
+//
+// Since 2.1
+func NewNamespace() *Namespace {
+	return &Namespace{
+		repositories:    map[string]Repository{},
+		middlewareChain: map[string][]RepositoryMiddleware{},
+	}
+}
+
+// Schemes returns the URI schemes which currently have a repository
+// registered in this Namespace, sorted in increasing lexical order.
+//
+// Since 2.1
+func (n *Namespace) Schemes() []string {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	schemes := make([]string, 0, len(n.repositories))
+	for scheme := range n.repositories {
+		schemes = append(schemes, scheme)
+	}
+
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Lookup returns the Repository instance which is registered to handle URIs
+// of the scheme of u.
+//
+// Before returning a repository, Lookup validates the structure of u and
+// returns an error wrapping ErrInvalidURI if it is not structurally valid,
+// then returns an error wrapping ErrSchemeNotRegistered if no repository is
+// registered for its scheme. If the registered repository implements
+// Validator, its ValidateURI() method is also called, and its error, if
+// any, is wrapped in ErrRepositoryUnavailable.
+//
+// NOTE: this method is intended to be used specifically by the storage
+// package. It generally should not be used outside of the fyne package -
+// instead you should use the methods in the storage package.
+func (n *Namespace) Lookup(u fyne.URI) (Repository, error) {
+	if err := validateURIStructure(u); err != nil {
+		return nil, err
+	}
+
+	n.lock.RLock()
+	repo, ok := n.repositories[u.Scheme()]
+	n.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSchemeNotRegistered, u.Scheme())
+	}
+
+	if v, ok := repo.(Validator); ok {
+		if err := v.ValidateURI(u); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRepositoryUnavailable, err)
+		}
+	}
+
+	return repo, nil
+}
+
+// Register registers repo to handle operations on URIs of the given scheme
+// within this Namespace.
+//
+// Any middleware previously registered for scheme with RegisterMiddleware
+// will be applied to repo, in the order it was registered.
+//
+// If a repository was already registered for scheme, its Destroy() method
+// is called, after it has been replaced, so that Destroy() implementations
+// are free to call back into this Namespace (for example to register a
+// fallback repository) without deadlocking.
+//
+// Since 2.1
+func (n *Namespace) Register(scheme string, repo Repository) {
+	n.lock.Lock()
+	old, hadOld := n.repositories[scheme]
+	n.repositories[scheme] = Compose(repo, n.middlewareChain[scheme]...)
+	n.lock.Unlock()
+
+	if hadOld {
+		old.Destroy()
+	}
+}
+
+// RegisterMiddleware appends wrap to the chain of middlewares that are
+// applied, in registration order, to the repository registered for scheme
+// within this Namespace.
+//
+// If a repository is already registered for scheme, it is immediately
+// re-wrapped with wrap. Otherwise, wrap is applied the next time Register is
+// called for scheme.
+//
+// Since 2.1
+func (n *Namespace) RegisterMiddleware(scheme string, wrap RepositoryMiddleware) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.middlewareChain[scheme] = append(n.middlewareChain[scheme], wrap)
+
+	if repo, ok := n.repositories[scheme]; ok {
+		n.repositories[scheme] = applyMiddleware(repo, wrap)
+	}
+}
+
+// Unregister removes the repository and any middleware registered for
+// scheme within this Namespace. If a repository was registered for scheme,
+// its Destroy() method is called after it has been removed, so that
+// Destroy() implementations are free to call back into this Namespace
+// without deadlocking.
+//
+// Since 2.1
+func (n *Namespace) Unregister(scheme string) {
+	n.lock.Lock()
+	repo, ok := n.repositories[scheme]
+	delete(n.repositories, scheme)
+	delete(n.middlewareChain, scheme)
+	n.lock.Unlock()
+
+	if ok {
+		repo.Destroy()
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNamespace_SchemesReflectsRegistrations(t *testing.T) {
+	ns := NewNamespace()
+	ns.Register("a", fakeRepository{})
+	ns.Register("b", fakeRepository{})
+
+	schemes := ns.Schemes()
+	if len(schemes) != 2 || schemes[0] != "a" || schemes[1] != "b" {
+		t.Fatalf("expected schemes [a b], got %v", schemes)
+	}
+
+	ns.Unregister("a")
+	schemes = ns.Schemes()
+	if len(schemes) != 1 || schemes[0] != "b" {
+		t.Fatalf("expected schemes [b] after Unregister, got %v", schemes)
+	}
+}
+
+// destroyTrackingRepository records whether Destroy was called on it.
+type destroyTrackingRepository struct {
+	fakeRepository
+	destroyed *bool
+}
+
+func (r destroyTrackingRepository) Destroy() {
+	*r.destroyed = true
+}
+
+func TestNamespace_UnregisterDestroysRepository(t *testing.T) {
+	ns := NewNamespace()
+	destroyed := false
+	ns.Register("test", destroyTrackingRepository{destroyed: &destroyed})
+
+	ns.Unregister("test")
+
+	if !destroyed {
+		t.Fatal("expected Unregister to call Destroy on the removed repository")
+	}
+}
+
+func TestNamespace_RegisterDestroysReplacedRepository(t *testing.T) {
+	ns := NewNamespace()
+	destroyed := false
+	ns.Register("test", destroyTrackingRepository{destroyed: &destroyed})
+
+	ns.Register("test", fakeRepository{})
+
+	if !destroyed {
+		t.Fatal("expected Register to call Destroy on the repository it replaces")
+	}
+}
+
+func TestNamespace_IsolatedFromDefaultNamespace(t *testing.T) {
+	defaultNamespace.Register("mem", fakeRepository{})
+	defer defaultNamespace.Unregister("mem")
+
+	ns := NewNamespace()
+
+	if _, err := ns.Lookup(&fakeURI{scheme: "mem"}); err == nil {
+		t.Fatal("expected a fresh Namespace to have no repositories registered, even if the default namespace does")
+	}
+}
+
+func TestNamespace_RegisterMiddlewareRewrapsExistingRepository(t *testing.T) {
+	ns := NewNamespace()
+	ns.Register("test", fakeRepository{})
+
+	calls := 0
+	ns.RegisterMiddleware("test", newCountingMiddleware(&calls))
+
+	repo, err := ns.Lookup(&fakeURI{scheme: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.Exists(&fakeURI{scheme: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected RegisterMiddleware to immediately re-wrap the already-registered repository, got %d calls", calls)
+	}
+}
+
+func TestNamespace_RegisterMiddlewareAppliedToFutureRegistration(t *testing.T) {
+	ns := NewNamespace()
+
+	calls := 0
+	ns.RegisterMiddleware("test", newCountingMiddleware(&calls))
+	ns.Register("test", fakeRepository{})
+
+	repo, err := ns.Lookup(&fakeURI{scheme: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.Exists(&fakeURI{scheme: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected middleware registered before Register to be applied to the new repository, got %d calls", calls)
+	}
+}
+
+// reregisteringRepository re-registers a plain fakeRepository for its own
+// scheme when destroyed, simulating a repository whose Destroy() installs a
+// fallback repository.
+type reregisteringRepository struct {
+	fakeRepository
+	ns     *Namespace
+	scheme string
+}
+
+func (r reregisteringRepository) Destroy() {
+	r.ns.Register(r.scheme, fakeRepository{})
+}
+
+func TestNamespace_RegisterDoesNotDeadlockWhenDestroyReenters(t *testing.T) {
+	ns := NewNamespace()
+	ns.Register("test", reregisteringRepository{ns: ns, scheme: "test"})
+
+	done := make(chan struct{})
+	go func() {
+		ns.Register("test", fakeRepository{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Register deadlocked when the replaced repository's Destroy() re-entered the Namespace")
+	}
+}
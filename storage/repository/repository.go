@@ -1,8 +1,6 @@
 package repository
 
 import (
-	"fmt"
-
 	"fyne.io/fyne"
 )
 
@@ -179,20 +177,175 @@ type MovableRepository interface {
 	Move(fyne.URI, fyne.URI) error
 }
 
+// RepositoryMiddleware wraps a Repository to add cross-cutting behaviour,
+// such as logging, caching, quota enforcement or permission checks, around
+// an already-registered Repository. A middleware is free to only implement
+// the base Repository interface - Compose() will detect which of the
+// extension interfaces (WriteableRepository, ListableRepository,
+// HierarchicalRepository, CopyableRepository, MovableRepository) are lost by
+// wrapping and fall back to the repository being wrapped for those, so a
+// caller doing e.g. `repo.(ListableRepository)` keeps working regardless of
+// which extension interfaces the middleware itself implements.
+//
+// Since 2.1
+type RepositoryMiddleware func(Repository) Repository
+
+// defaultNamespace is the Namespace used by the package-level Register,
+// RegisterMiddleware and RegisteredRepository functions.
+var defaultNamespace = NewNamespace()
+
 // Register registers a storage repository so that operations on URIs of the
 // registered scheme will use methods implemented by the relevant repository
 // implementation.
 //
+// Any middleware previously registered for scheme with RegisterMiddleware
+// will be applied to repository, in the order it was registered.
+//
+// This is a convenience wrapper around defaultNamespace.Register() - see
+// Namespace for a way to register repositories in an isolated namespace,
+// for example for testing.
+//
 // Since 2.0.0
 func Register(scheme string, repository Repository) {
+	defaultNamespace.Register(scheme, repository)
+}
+
+// RegisterMiddleware appends wrap to the chain of middlewares that are
+// applied, in registration order, to the repository registered for scheme.
+//
+// If a repository is already registered for scheme, it is immediately
+// re-wrapped with wrap. Otherwise, wrap is applied the next time Register is
+// called for scheme.
+//
+// This is a convenience wrapper around defaultNamespace.RegisterMiddleware().
+//
+// Since 2.1
+func RegisterMiddleware(scheme string, wrap RepositoryMiddleware) {
+	defaultNamespace.RegisterMiddleware(scheme, wrap)
+}
+
+// Compose builds a Repository by applying each of wrappers to base in turn,
+// each one wrapping the result of the previous, and returns the result.
+//
+// After every wrapper is applied, Compose re-derives the set of extension
+// interfaces (WriteableRepository, ListableRepository, HierarchicalRepository,
+// CopyableRepository, MovableRepository) implemented by the chain so far, so
+// that a wrapper which only implements the base Repository interface does
+// not strip extension interfaces implemented further down the chain. The
+// returned Repository implements exactly the union of extension interfaces
+// implemented by base and by wrappers.
+//
+// Since 2.1
+func Compose(base Repository, wrappers ...RepositoryMiddleware) Repository {
+	result := base
+	for _, wrap := range wrappers {
+		result = applyMiddleware(result, wrap)
+	}
+
+	return result
+}
+
+// applyMiddleware wraps prev with wrap, then rebuilds a composite value that
+// implements every extension interface implemented by either the wrapped
+// value or prev, so that extension interfaces are never silently lost.
+func applyMiddleware(prev Repository, wrap RepositoryMiddleware) Repository {
+	return mergeCapabilities(wrap(prev), prev)
+}
+
+// The following interfaces describe only the methods added by each
+// extension interface, without re-embedding Repository. They exist so that
+// mergeCapabilities can compose a value out of independently-sourced
+// method sets without running into ambiguous method promotion, which would
+// happen if two embedded fields both re-declared the Repository methods.
+type (
+	writeableOnly interface {
+		Writer(u fyne.URI) (fyne.URIWriteCloser, error)
+		CanWrite(u fyne.URI) (bool, error)
+		Delete(u fyne.URI) error
+	}
+
+	listableOnly interface {
+		CanList(u fyne.URI) (bool, error)
+		List(u fyne.URI) ([]fyne.URI, error)
+	}
+
+	hierarchicalOnly interface {
+		Parent(fyne.URI) (fyne.URI, error)
+		Child(fyne.URI) (fyne.URI, error)
+	}
+
+	copyableOnly interface {
+		Copy(fyne.URI, fyne.URI) error
+	}
+
+	movableOnly interface {
+		Move(fyne.URI, fyne.URI) error
+	}
+)
+
+//go:generate go run ./internal/genmerge
+
+// mergeCapabilities returns a Repository which uses wrapped to satisfy the
+// base Repository methods, but which implements exactly the set of
+// extension interfaces implemented by wrapped, falling back to prev for any
+// extension interface wrapped does not itself implement. The actual
+// composition, which needs a distinct concrete type per combination of
+// extension interfaces so that a type assertion for an interface neither
+// wrapped nor prev implements continues to fail, is generated - see
+// composeCapabilities in merge_generated.go and internal/genmerge.
+func mergeCapabilities(wrapped, prev Repository) Repository {
+	var w writeableOnly
+	if x, ok := wrapped.(WriteableRepository); ok {
+		w = x
+	} else if x, ok := prev.(WriteableRepository); ok {
+		w = x
+	}
+
+	var l listableOnly
+	if x, ok := wrapped.(ListableRepository); ok {
+		l = x
+	} else if x, ok := prev.(ListableRepository); ok {
+		l = x
+	}
+
+	var h hierarchicalOnly
+	if x, ok := wrapped.(HierarchicalRepository); ok {
+		h = x
+	} else if x, ok := prev.(HierarchicalRepository); ok {
+		h = x
+	}
+
+	var c copyableOnly
+	if x, ok := wrapped.(CopyableRepository); ok {
+		c = x
+	} else if x, ok := prev.(CopyableRepository); ok {
+		c = x
+	}
+
+	var m movableOnly
+	if x, ok := wrapped.(MovableRepository); ok {
+		m = x
+	} else if x, ok := prev.(MovableRepository); ok {
+		m = x
+	}
+
+	return composeCapabilities(wrapped, w, l, h, c, m)
 }
 
 // RegisteredRepository returns the Repository instance which is registered to
 // handle URIs of the given scheme.
 //
+// If u is not structurally valid, an error wrapping ErrInvalidURI is
+// returned. If no repository is registered for the scheme of u, an error
+// wrapping ErrSchemeNotRegistered is returned. If the registered repository
+// implements Validator and rejects u, an error wrapping
+// ErrRepositoryUnavailable is returned.
+//
+// This is a convenience wrapper around defaultNamespace.Lookup().
+//
 // NOTE: this function is intended to be used specifically by the storage
 // package. It generally should not be used outside of the fyne package -
 // instead you should use the methods in the storage package.
 func RegisteredRepository(u fyne.URI) (Repository, error) {
-	return nil, fmt.Errorf("TODO")
+	return defaultNamespace.Lookup(u)
 }
\ No newline at end of file
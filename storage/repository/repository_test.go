@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+)
+
+// fakeURI is a minimal fyne.URI used to exercise the repository package
+// without depending on a concrete URI implementation.
+type fakeURI struct {
+	scheme string
+}
+
+func (u *fakeURI) Extension() string      { return "" }
+func (u *fakeURI) Name() string           { return "" }
+func (u *fakeURI) MimeType() string       { return "" }
+func (u *fakeURI) Scheme() string         { return u.scheme }
+func (u *fakeURI) String() string         { return u.scheme + "://fake" }
+func (u *fakeURI) Authority() string      { return "" }
+func (u *fakeURI) Path() string           { return "" }
+func (u *fakeURI) Query() string          { return "" }
+func (u *fakeURI) Fragment() string       { return "" }
+func (u *fakeURI) Equals(o fyne.URI) bool { return o != nil && o.String() == u.String() }
+
+// fakeRepository implements only the base Repository interface.
+type fakeRepository struct{}
+
+func (fakeRepository) Exists(u fyne.URI) (bool, error)                   { return true, nil }
+func (fakeRepository) ReaderFrom(u fyne.URI) (fyne.URIReadCloser, error) { return nil, nil }
+func (fakeRepository) CanRead(u fyne.URI) (bool, error)                  { return true, nil }
+func (fakeRepository) Destroy()                                          {}
+
+// fakeListableRepository additionally implements ListableRepository.
+type fakeListableRepository struct {
+	fakeRepository
+}
+
+func (fakeListableRepository) CanList(u fyne.URI) (bool, error)    { return true, nil }
+func (fakeListableRepository) List(u fyne.URI) ([]fyne.URI, error) { return nil, nil }
+
+// countingMiddleware wraps a Repository and only implements the base
+// Repository interface itself, counting calls to Exists. It is
+// representative of a logging/caching middleware that does not care about
+// any extension interface the repository it wraps might implement.
+type countingMiddleware struct {
+	Repository
+	calls *int
+}
+
+func (r *countingMiddleware) Exists(u fyne.URI) (bool, error) {
+	*r.calls++
+	return r.Repository.Exists(u)
+}
+
+func newCountingMiddleware(calls *int) RepositoryMiddleware {
+	return func(r Repository) Repository {
+		return &countingMiddleware{Repository: r, calls: calls}
+	}
+}
+
+func TestCompose_PreservesCapabilityThroughUnawareMiddleware(t *testing.T) {
+	base := fakeListableRepository{}
+	calls := 0
+
+	composed := Compose(base, newCountingMiddleware(&calls))
+
+	listable, ok := composed.(ListableRepository)
+	if !ok {
+		t.Fatal("Compose lost ListableRepository capability of the wrapped repository")
+	}
+
+	if _, err := composed.Exists(&fakeURI{scheme: "fake"}); err != nil {
+		t.Fatalf("unexpected error from Exists: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected middleware to intercept Exists once, got %d calls", calls)
+	}
+
+	if _, err := listable.List(&fakeURI{scheme: "fake"}); err != nil {
+		t.Fatalf("unexpected error from List: %v", err)
+	}
+}
+
+func TestCompose_ChainDoesNotDropCapability(t *testing.T) {
+	base := fakeListableRepository{}
+	var calls [2]int
+
+	composed := Compose(base, newCountingMiddleware(&calls[0]), newCountingMiddleware(&calls[1]))
+
+	if _, ok := composed.(ListableRepository); !ok {
+		t.Fatal("chaining two capability-unaware middlewares lost ListableRepository")
+	}
+
+	if _, err := composed.Exists(&fakeURI{scheme: "fake"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != [2]int{1, 1} {
+		t.Fatalf("expected both middlewares to run exactly once, got %v", calls)
+	}
+}
+
+func TestCompose_DoesNotFabricateCapability(t *testing.T) {
+	base := fakeRepository{}
+
+	composed := Compose(base, newCountingMiddleware(new(int)), newCountingMiddleware(new(int)))
+
+	if _, ok := composed.(ListableRepository); ok {
+		t.Fatal("Compose fabricated a ListableRepository capability the base repository never had")
+	}
+}